@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Cloudflare, Inc.
+// Licensed under the Apache 2.0 license found in the LICENSE file or at:
+//     https://opensource.org/licenses/Apache-2.0
+
+package search
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type equalConstraint struct {
+	path string
+	val  parquet.Value
+
+	// comp is nil if the column is not part of the schema
+	comp func(parquet.Value, parquet.Value) int
+	idx  int
+	// optional is true if the column may hold null values
+	optional bool
+}
+
+// Equal returns a constraint that matches rows whose value in the column at path is equal to val.
+// Null values and columns that are absent from the schema are treated as empty, so an empty val
+// matches rows that lack the column as well as rows that hold an explicit empty value.
+// Pages are pruned using the column index when it is present. Byte array bounds in the column
+// index are truncated to the writer's ColumnIndexSizeLimit (16 bytes by default in parquet-go),
+// so values sharing a longer prefix cannot be told apart and their pages are all scanned.
+func Equal(path string, val parquet.Value) Constraint {
+	return &equalConstraint{path: path, val: val}
+}
+
+func (ec *equalConstraint) rowRanges(rg parquet.RowGroup, rr []RowRange) ([]RowRange, error) {
+	if len(rr) == 0 {
+		return nil, nil
+	}
+	if ec.comp == nil {
+		if isEmpty(ec.val) {
+			return rr, nil
+		}
+		return nil, nil
+	}
+
+	cc := rg.ColumnChunks()[ec.idx]
+	pgs := cc.Pages()
+	defer func() { _ = pgs.Close() }()
+
+	// the column index lets us skip pages that cannot contain the value, but it is optional
+	ci, err := cc.ColumnIndex()
+	if err != nil {
+		return ec.scanPages(pgs, rr)
+	}
+	oi, err := cc.OffsetIndex()
+	if err != nil {
+		return ec.scanPages(pgs, rr)
+	}
+	return ec.scanIndexedPages(pgs, ci, oi, rg.NumRows(), rr)
+}
+
+// scanPages reads every page sequentially but only decodes the pages that overlap rr.
+func (ec *equalConstraint) scanPages(pgs parquet.Pages, rr []RowRange) ([]RowRange, error) {
+	res := make([]RowRange, 0)
+	buf := make([]parquet.Value, 1024)
+	// r is the first range of rr that does not end before the current page
+	for off, r := int64(0), 0; r < len(rr); {
+		pg, err := pgs.ReadPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read page: %w", err)
+		}
+		n := pg.NumRows()
+		if r = skipRowRanges(rr, r, off); r < len(rr) && rr[r].From < off+n {
+			res, err = ec.scanPage(res, buf, pg, off)
+		}
+		parquet.Release(pg)
+		if err != nil {
+			return nil, err
+		}
+		off += n
+	}
+
+	return res, nil
+}
+
+// scanIndexedPages scans the pages that overlap rr and whose column index bounds may contain the value.
+func (ec *equalConstraint) scanIndexedPages(pgs parquet.Pages, ci parquet.ColumnIndex, oi parquet.OffsetIndex, numRows int64, rr []RowRange) ([]RowRange, error) {
+	res := make([]RowRange, 0)
+	buf := make([]parquet.Value, 1024)
+	// r is the first range of rr that does not end before the current page,
+	// next is the page the reader is positioned at, we only seek when we skipped pages
+	for p, r, next := 0, 0, 0; p < oi.NumPages(); p++ {
+		pfrom, pto := oi.FirstRowIndex(p), numRows
+		if p+1 < oi.NumPages() {
+			pto = oi.FirstRowIndex(p + 1)
+		}
+		if r = skipRowRanges(rr, r, pfrom); r == len(rr) {
+			break
+		}
+		// the page falls in a gap between ranges of rr
+		if rr[r].From >= pto || !ec.mayMatchPage(ci, p) {
+			continue
+		}
+		if p != next {
+			if err := pgs.SeekToRow(pfrom); err != nil {
+				return nil, fmt.Errorf("unable to seek to row: %w", err)
+			}
+		}
+		pg, err := pgs.ReadPage()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read page: %w", err)
+		}
+		res, err = ec.scanPage(res, buf, pg, pfrom)
+		parquet.Release(pg)
+		if err != nil {
+			return nil, err
+		}
+		next = p + 1
+	}
+
+	return res, nil
+}
+
+// scanPage appends the rows of the page that match the value to rr, off is the index of the first row of the page.
+func (ec *equalConstraint) scanPage(rr []RowRange, buf []parquet.Value, pg parquet.Page, off int64) ([]RowRange, error) {
+	vr := pg.Values()
+	for row := off; ; {
+		n, err := vr.ReadValues(buf)
+		for i := range buf[:n] {
+			if ec.matches(buf[i]) {
+				rr = appendRow(rr, row+int64(i))
+			}
+		}
+		row += int64(n)
+		if err == io.EOF {
+			return rr, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read values: %w", err)
+		}
+	}
+}
+
+func (ec *equalConstraint) init(s *parquet.Schema) error {
+	c, ok := s.Lookup(ec.path)
+	if !ok {
+		ec.comp = nil
+		return nil
+	}
+	if c.MaxRepetitionLevel > 0 {
+		return fmt.Errorf("unable to search repeated column %q", ec.path)
+	}
+	if kind := c.Node.Type().Kind(); !isEmpty(ec.val) && kind != ec.val.Kind() {
+		return fmt.Errorf("unable to search value of kind %s in column %q of kind %s", ec.val.Kind(), ec.path, kind)
+	}
+	ec.comp = c.Node.Type().Compare
+	ec.idx = c.ColumnIndex
+	ec.optional = c.MaxDefinitionLevel > 0
+	return nil
+}
+
+// mayMatchPage reports whether page p may contain the value according to its column index bounds.
+func (ec *equalConstraint) mayMatchPage(ci parquet.ColumnIndex, p int) bool {
+	if ci.NullPage(p) {
+		return isEmpty(ec.val)
+	}
+	if isEmpty(ec.val) {
+		// null counts are optional in the column index and read as 0 when missing,
+		// so they cannot prove that a page of an optional column holds no nulls
+		return ec.optional || isEmpty(ci.MinValue(p))
+	}
+	return ec.comp(ci.MinValue(p), ec.val) <= 0 && ec.comp(ec.val, ci.MaxValue(p)) <= 0
+}
+
+func (ec *equalConstraint) matches(v parquet.Value) bool {
+	if isEmpty(v) || isEmpty(ec.val) {
+		return isEmpty(v) && isEmpty(ec.val)
+	}
+	return ec.comp(v, ec.val) == 0
+}
+
+type notConstraint struct {
+	c Constraint
+}
+
+// Not returns a constraint that matches the rows that c does not match.
+func Not(c Constraint) Constraint {
+	return &notConstraint{c: c}
+}
+
+func (nc *notConstraint) rowRanges(rg parquet.RowGroup, rr []RowRange) ([]RowRange, error) {
+	srr, err := nc.c.rowRanges(rg, rr)
+	if err != nil {
+		return nil, err
+	}
+	return complementRowRanges(rr, simplify(srr)), nil
+}
+
+func (nc *notConstraint) init(s *parquet.Schema) error {
+	return nc.c.init(s)
+}
+
+func isEmpty(v parquet.Value) bool {
+	return v.IsNull() || (v.Kind() == parquet.ByteArray && len(v.ByteArray()) == 0)
+}
+
+// skipRowRanges advances the cursor r past the ranges of rr that end before row.
+func skipRowRanges(rr []RowRange, r int, row int64) int {
+	for r < len(rr) && rr[r].From+rr[r].Count <= row {
+		r++
+	}
+	return r
+}
+
+// appendRow appends a single row to rr, extending the last range if the row is adjacent to it.
+func appendRow(rr []RowRange, row int64) []RowRange {
+	if n := len(rr); n > 0 && rr[n-1].From+rr[n-1].Count == row {
+		rr[n-1].Count++
+		return rr
+	}
+	return append(rr, RowRange{From: row, Count: 1})
+}
@@ -0,0 +1,400 @@
+// Copyright (c) 2025 Cloudflare, Inc.
+// Licensed under the Apache 2.0 license found in the LICENSE file or at:
+//     https://opensource.org/licenses/Apache-2.0
+
+package search
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// writeFile writes every batch of rows with its own call to Write and returns the file contents.
+func writeFile[T any](t testing.TB, batches [][]T, opts ...parquet.WriterOption) []byte {
+	buf := bytes.NewBuffer(nil)
+	w := parquet.NewGenericWriter[T](buf, opts...)
+	for i := range batches {
+		if _, err := w.Write(batches[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func openFile(t testing.TB, data []byte) *parquet.File {
+	reader := bytes.NewReader(data)
+	file, err := parquet.OpenFile(reader, reader.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func buildFile[T any](t testing.TB, rows []T, opts ...parquet.WriterOption) *parquet.File {
+	return openFile(t, writeFile(t, [][]T{rows}, opts...))
+}
+
+// buildPagePerRowFile writes every row in its own page.
+func buildPagePerRowFile[T any](t testing.TB, rows []T) *parquet.File {
+	batches := make([][]T, len(rows))
+	for i := range rows {
+		batches[i] = rows[i : i+1]
+	}
+	return openFile(t, writeFile(t, batches, parquet.PageBufferSize(1)))
+}
+
+// countingRowGroup counts the pages read from its column chunks and can hide their column indexes.
+type countingRowGroup struct {
+	parquet.RowGroup
+
+	noColumnIndex bool
+	noNullCounts  bool
+	pages         int
+}
+
+func (rg *countingRowGroup) ColumnChunks() []parquet.ColumnChunk {
+	ccs := rg.RowGroup.ColumnChunks()
+	res := make([]parquet.ColumnChunk, len(ccs))
+	for i := range ccs {
+		res[i] = &countingColumnChunk{ColumnChunk: ccs[i], rg: rg}
+	}
+	return res
+}
+
+type countingColumnChunk struct {
+	parquet.ColumnChunk
+
+	rg *countingRowGroup
+}
+
+func (cc *countingColumnChunk) Pages() parquet.Pages {
+	return &countingPages{Pages: cc.ColumnChunk.Pages(), rg: cc.rg}
+}
+
+func (cc *countingColumnChunk) ColumnIndex() (parquet.ColumnIndex, error) {
+	if cc.rg.noColumnIndex {
+		return nil, parquet.ErrMissingColumnIndex
+	}
+	ci, err := cc.ColumnChunk.ColumnIndex()
+	if err != nil || !cc.rg.noNullCounts {
+		return ci, err
+	}
+	return &noNullCountsColumnIndex{ColumnIndex: ci}, nil
+}
+
+// noNullCountsColumnIndex mimics writers that omit the optional null counts of the column index.
+type noNullCountsColumnIndex struct {
+	parquet.ColumnIndex
+}
+
+func (ci *noNullCountsColumnIndex) NullCount(int) int64 { return 0 }
+
+type countingPages struct {
+	parquet.Pages
+
+	rg *countingRowGroup
+}
+
+func (p *countingPages) ReadPage() (parquet.Page, error) {
+	pg, err := p.Pages.ReadPage()
+	if err == nil {
+		p.rg.pages++
+	}
+	return pg, err
+}
+
+func TestEqual(t *testing.T) {
+	type row struct {
+		Job *string `parquet:"job,optional"`
+	}
+	val := func(s string) *string { return &s }
+
+	// rows with the label present, explicitly empty and absent
+	rows := []row{
+		{Job: val("a")},
+		{Job: val("b")},
+		{Job: val("")},
+		{Job: nil},
+		{Job: val("a")},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		cs     []Constraint
+		expect []RowRange
+	}{
+		{
+			name:   "present value",
+			cs:     []Constraint{Equal("job", parquet.ValueOf("a"))},
+			expect: []RowRange{{From: 0, Count: 1}, {From: 4, Count: 1}},
+		},
+		{
+			name:   "empty value matches empty and null",
+			cs:     []Constraint{Equal("job", parquet.ValueOf(""))},
+			expect: []RowRange{{From: 2, Count: 2}},
+		},
+		{
+			name:   "null value matches empty and null",
+			cs:     []Constraint{Equal("job", parquet.NullValue())},
+			expect: []RowRange{{From: 2, Count: 2}},
+		},
+		{
+			name:   "not empty value matches present labels",
+			cs:     []Constraint{Not(Equal("job", parquet.ValueOf("")))},
+			expect: []RowRange{{From: 0, Count: 2}, {From: 4, Count: 1}},
+		},
+		{
+			name:   "multiple constraints on the same column",
+			cs:     []Constraint{Not(Equal("job", parquet.ValueOf(""))), Not(Equal("job", parquet.ValueOf("a")))},
+			expect: []RowRange{{From: 1, Count: 1}},
+		},
+		{
+			name:   "absent column matches empty value",
+			cs:     []Constraint{Equal("missing", parquet.ValueOf(""))},
+			expect: []RowRange{{From: 0, Count: 5}},
+		},
+		{
+			name:   "absent column does not match not empty value",
+			cs:     []Constraint{Not(Equal("missing", parquet.ValueOf("")))},
+			expect: []RowRange{},
+		},
+		{
+			name:   "absent column does not match present value",
+			cs:     []Constraint{Equal("missing", parquet.ValueOf("a"))},
+			expect: []RowRange{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// with a page per row the null and empty values end up in pages
+			// whose column index bounds need to be interpreted
+			for _, file := range []*parquet.File{buildFile(t, rows), buildPagePerRowFile(t, rows)} {
+				for _, noColumnIndex := range []bool{false, true} {
+					if err := Initialize(file.Schema(), tt.cs...); err != nil {
+						t.Fatal(err)
+					}
+					rg := &countingRowGroup{RowGroup: file.RowGroups()[0], noColumnIndex: noColumnIndex}
+					rr, err := Filter(rg, tt.cs...)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !slices.Equal(rr, tt.expect) {
+						t.Fatalf("Expected %v to match %v", rr, tt.expect)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEqualMissingNullCounts(t *testing.T) {
+	type row struct {
+		Job *string `parquet:"job,optional"`
+	}
+	val := func(s string) *string { return &s }
+
+	// a single page holding nulls and values
+	file := buildFile(t, []row{{Job: val("a")}, {Job: nil}, {Job: val("a")}})
+
+	for _, tt := range []struct {
+		name   string
+		c      Constraint
+		expect []RowRange
+	}{
+		{
+			name:   "empty value matches null",
+			c:      Equal("job", parquet.ValueOf("")),
+			expect: []RowRange{{From: 1, Count: 1}},
+		},
+		{
+			name:   "not empty value does not match null",
+			c:      Not(Equal("job", parquet.ValueOf(""))),
+			expect: []RowRange{{From: 0, Count: 1}, {From: 2, Count: 1}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Initialize(file.Schema(), tt.c); err != nil {
+				t.Fatal(err)
+			}
+			rg := &countingRowGroup{RowGroup: file.RowGroups()[0], noNullCounts: true}
+			rr, err := Filter(rg, tt.c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(rr, tt.expect) {
+				t.Fatalf("Expected %v to match %v", rr, tt.expect)
+			}
+		})
+	}
+}
+
+func TestEqualMultiplePages(t *testing.T) {
+	type row struct {
+		Job string `parquet:"job"`
+	}
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i].Job = "a"
+		if i%100 == 0 {
+			rows[i].Job = "b"
+		}
+	}
+	file := buildFile(t, rows, parquet.PageBufferSize(64))
+
+	c := Equal("job", parquet.ValueOf("b"))
+	if err := Initialize(file.Schema(), c); err != nil {
+		t.Fatal(err)
+	}
+	rr, err := Filter(file.RowGroups()[0], c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := make([]RowRange, 0, 10)
+	for i := range 10 {
+		expect = append(expect, RowRange{From: int64(i * 100), Count: 1})
+	}
+	if !slices.Equal(rr, expect) {
+		t.Fatalf("Expected %v to match %v", rr, expect)
+	}
+}
+
+func TestEqualColumnIndex(t *testing.T) {
+	type row struct {
+		Name string `parquet:"name"`
+	}
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i].Name = fmt.Sprintf("metric_%03d", i/10)
+	}
+	file := buildFile(t, rows, parquet.PageBufferSize(64))
+
+	c := Equal("name", parquet.ValueOf("metric_042"))
+	if err := Initialize(file.Schema(), c); err != nil {
+		t.Fatal(err)
+	}
+	expect := []RowRange{{From: 420, Count: 10}}
+
+	indexed := &countingRowGroup{RowGroup: file.RowGroups()[0]}
+	rr, err := Filter(indexed, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(rr, expect) {
+		t.Fatalf("Expected %v to match %v", rr, expect)
+	}
+
+	unindexed := &countingRowGroup{RowGroup: file.RowGroups()[0], noColumnIndex: true}
+	rr, err = Filter(unindexed, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(rr, expect) {
+		t.Fatalf("Expected %v to match %v", rr, expect)
+	}
+
+	if indexed.pages > 2 || indexed.pages >= unindexed.pages {
+		t.Fatalf("Expected column index to skip pages, read %d pages with and %d pages without it", indexed.pages, unindexed.pages)
+	}
+}
+
+func TestEqualSparseRowRanges(t *testing.T) {
+	type row struct {
+		Name string `parquet:"name"`
+	}
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i].Name = "metric"
+	}
+	file := buildFile(t, rows, parquet.PageBufferSize(64))
+	oi, err := file.RowGroups()[0].ColumnChunks()[0].OffsetIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.NumPages() <= 2 {
+		t.Fatalf("Expected more than 2 pages, got %d", oi.NumPages())
+	}
+
+	// every page may contain the value, so only the ranges left by the earlier constraint can prune pages
+	expect := []RowRange{{From: 0, Count: 1}, {From: 999, Count: 1}}
+	for _, noColumnIndex := range []bool{false, true} {
+		t.Run(fmt.Sprintf("noColumnIndex=%t", noColumnIndex), func(t *testing.T) {
+			c := Equal("name", parquet.ValueOf("metric"))
+			if err := Initialize(file.Schema(), c); err != nil {
+				t.Fatal(err)
+			}
+			rg := &countingRowGroup{RowGroup: file.RowGroups()[0], noColumnIndex: noColumnIndex}
+			rr, err := Filter(rg, &fixedConstraint{rr: expect}, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(rr, expect) {
+				t.Fatalf("Expected %v to match %v", rr, expect)
+			}
+			if noColumnIndex {
+				return
+			}
+			if rg.pages != 2 {
+				t.Fatalf("Expected to read the first and last page only, read %d pages", rg.pages)
+			}
+		})
+	}
+}
+
+func BenchmarkEqual(b *testing.B) {
+	type row struct {
+		Name string `parquet:"name"`
+	}
+	// column index bounds of byte arrays are truncated to the writer's ColumnIndexSizeLimit,
+	// names sharing a prefix longer than the limit get identical bounds on every page
+	for _, bc := range []struct {
+		name   string
+		format string
+		opts   []parquet.WriterOption
+	}{
+		{name: "short names", format: "metric_%05d"},
+		{name: "long names", format: "http_server_requests_%07d"},
+		{name: "long names with larger size limit", format: "http_server_requests_%07d", opts: []parquet.WriterOption{parquet.ColumnIndexSizeLimit(64)}},
+	} {
+		rows := make([]row, 100_000)
+		for i := range rows {
+			rows[i].Name = fmt.Sprintf(bc.format, i/100)
+		}
+		file := buildFile(b, rows, append(bc.opts, parquet.PageBufferSize(4*1024))...)
+
+		for _, noColumnIndex := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/noColumnIndex=%t", bc.name, noColumnIndex), func(b *testing.B) {
+				c := Equal("name", parquet.ValueOf(fmt.Sprintf(bc.format, 500)))
+				if err := Initialize(file.Schema(), c); err != nil {
+					b.Fatal(err)
+				}
+				rg := &countingRowGroup{RowGroup: file.RowGroups()[0], noColumnIndex: noColumnIndex}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					rg.pages = 0
+					if _, err := Filter(rg, c); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(rg.pages), "pages/op")
+			})
+		}
+	}
+}
+
+func TestEqualKindMismatch(t *testing.T) {
+	type row struct {
+		Job string `parquet:"job"`
+	}
+	file := buildFile(t, []row{{Job: "a"}})
+	if err := Initialize(file.Schema(), Equal("job", parquet.ValueOf(int64(1)))); err == nil {
+		t.Fatal("Expected an error for mismatching value kind")
+	}
+}
@@ -8,24 +8,38 @@ import (
 	"sort"
 )
 
-type rowRange struct {
-	from  int64
-	count int64
+// RowRange is a range of rows within a row group, starting at row From and spanning Count rows.
+type RowRange struct {
+	From  int64
+	Count int64
+}
+
+// RowRanges is a set of non-overlapping increasing row ranges within a row group.
+type RowRanges []RowRange
+
+// NumRows returns the number of rows covered by the row ranges.
+// It is derived from the ranges alone and does not touch the row group.
+func (rr RowRanges) NumRows() int64 {
+	var res int64
+	for i := range rr {
+		res += rr[i].Count
+	}
+	return res
 }
 
 // intersect intersects the row ranges from left hand sight with the row ranges from rhs
 // it assumes that lhs and rhs are simplified and returns a simplified result.
 // it operates in o(l+r) time by cursoring through ranges with a two pointer approach.
-func intersectRowRanges(lhs []rowRange, rhs []rowRange) []rowRange {
-	res := make([]rowRange, 0)
+func intersectRowRanges(lhs []RowRange, rhs []RowRange) []RowRange {
+	res := make([]RowRange, 0)
 	for l, r := 0, 0; l < len(lhs) && r < len(rhs); {
-		al, bl := lhs[l].from, lhs[l].from+lhs[l].count
-		ar, br := rhs[r].from, rhs[r].from+rhs[r].count
+		al, bl := lhs[l].From, lhs[l].From+lhs[l].Count
+		ar, br := rhs[r].From, rhs[r].From+rhs[r].Count
 
 		// check if rows intersect
 		if al <= br && ar <= bl {
 			os, oe := max(al, ar), min(bl, br)
-			res = append(res, rowRange{from: os, count: oe - os})
+			res = append(res, RowRange{From: os, Count: oe - os})
 		}
 
 		// advance the cursor of the range that ends first
@@ -39,21 +53,48 @@ func intersectRowRanges(lhs []rowRange, rhs []rowRange) []rowRange {
 	return simplify(res)
 }
 
-func simplify(rr []rowRange) []rowRange {
+// complementRowRanges returns the rows of lhs that are not covered by any range of rhs.
+// it assumes that lhs and rhs are simplified and returns a simplified result.
+func complementRowRanges(lhs []RowRange, rhs []RowRange) []RowRange {
+	res := make([]RowRange, 0)
+	r := 0
+	for _, lr := range lhs {
+		from, to := lr.From, lr.From+lr.Count
+
+		// skip the ranges that end before the current range starts
+		for r < len(rhs) && rhs[r].From+rhs[r].Count <= from {
+			r++
+		}
+		// a range from rhs may span multiple ranges from lhs, so we don't advance r here
+		for i := r; i < len(rhs) && rhs[i].From < to; i++ {
+			if rhs[i].From > from {
+				res = append(res, RowRange{From: from, Count: rhs[i].From - from})
+			}
+			from = max(from, rhs[i].From+rhs[i].Count)
+		}
+		if from < to {
+			res = append(res, RowRange{From: from, Count: to - from})
+		}
+	}
+
+	return simplify(res)
+}
+
+func simplify(rr []RowRange) []RowRange {
 	if len(rr) == 0 {
 		return nil
 	}
 
 	sort.Slice(rr, func(i, j int) bool {
-		return rr[i].from < rr[j].from
+		return rr[i].From < rr[j].From
 	})
 
-	tmp := make([]rowRange, 0)
+	tmp := make([]RowRange, 0)
 	l := rr[0]
 	for i := 1; i < len(rr); i++ {
 		r := rr[i]
-		al, bl := l.from, l.from+l.count
-		ar, br := r.from, r.from+r.count
+		al, bl := l.From, l.From+l.Count
+		ar, br := r.From, r.From+r.Count
 		if bl < ar {
 			tmp = append(tmp, l)
 			l = r
@@ -66,16 +107,16 @@ func simplify(rr []rowRange) []rowRange {
 			continue
 		}
 
-		l = rowRange{
-			from:  from,
-			count: count,
+		l = RowRange{
+			From:  from,
+			Count: count,
 		}
 	}
 
 	tmp = append(tmp, l)
-	res := make([]rowRange, 0, len(tmp))
+	res := make([]RowRange, 0, len(tmp))
 	for i := range tmp {
-		if tmp[i].count != 0 {
+		if tmp[i].Count != 0 {
 			res = append(res, tmp[i])
 		}
 	}
@@ -10,66 +10,66 @@ import (
 )
 
 func TestIntersect(t *testing.T) {
-	for _, tt := range []struct{ lhs, rhs, expect []rowRange }{
+	for _, tt := range []struct{ lhs, rhs, expect []RowRange }{
 		{
-			lhs:    []rowRange{{from: 0, count: 4}},
-			rhs:    []rowRange{{from: 2, count: 6}},
-			expect: []rowRange{{from: 2, count: 2}},
+			lhs:    []RowRange{{From: 0, Count: 4}},
+			rhs:    []RowRange{{From: 2, Count: 6}},
+			expect: []RowRange{{From: 2, Count: 2}},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 4}},
-			rhs:    []rowRange{{from: 6, count: 8}},
-			expect: []rowRange{},
+			lhs:    []RowRange{{From: 0, Count: 4}},
+			rhs:    []RowRange{{From: 6, Count: 8}},
+			expect: []RowRange{},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 4}},
-			rhs:    []rowRange{{from: 0, count: 4}},
-			expect: []rowRange{{from: 0, count: 4}},
+			lhs:    []RowRange{{From: 0, Count: 4}},
+			rhs:    []RowRange{{From: 0, Count: 4}},
+			expect: []RowRange{{From: 0, Count: 4}},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 4}, {from: 8, count: 2}},
-			rhs:    []rowRange{{from: 2, count: 9}},
-			expect: []rowRange{{from: 2, count: 2}, {from: 8, count: 2}},
+			lhs:    []RowRange{{From: 0, Count: 4}, {From: 8, Count: 2}},
+			rhs:    []RowRange{{From: 2, Count: 9}},
+			expect: []RowRange{{From: 2, Count: 2}, {From: 8, Count: 2}},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 1}, {from: 4, count: 1}},
-			rhs:    []rowRange{{from: 2, count: 1}, {from: 6, count: 1}},
-			expect: []rowRange{},
+			lhs:    []RowRange{{From: 0, Count: 1}, {From: 4, Count: 1}},
+			rhs:    []RowRange{{From: 2, Count: 1}, {From: 6, Count: 1}},
+			expect: []RowRange{},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 2}, {from: 2, count: 2}},
-			rhs:    []rowRange{{from: 1, count: 2}, {from: 3, count: 2}},
-			expect: []rowRange{{from: 1, count: 3}},
+			lhs:    []RowRange{{From: 0, Count: 2}, {From: 2, Count: 2}},
+			rhs:    []RowRange{{From: 1, Count: 2}, {From: 3, Count: 2}},
+			expect: []RowRange{{From: 1, Count: 3}},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 2}, {from: 5, count: 2}},
-			rhs:    []rowRange{{from: 0, count: 10}},
-			expect: []rowRange{{from: 0, count: 2}, {from: 5, count: 2}},
+			lhs:    []RowRange{{From: 0, Count: 2}, {From: 5, Count: 2}},
+			rhs:    []RowRange{{From: 0, Count: 10}},
+			expect: []RowRange{{From: 0, Count: 2}, {From: 5, Count: 2}},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 2}, {from: 3, count: 1}, {from: 5, count: 2}, {from: 12, count: 10}},
-			rhs:    []rowRange{{from: 0, count: 10}, {from: 15, count: 32}},
-			expect: []rowRange{{from: 0, count: 2}, {from: 3, count: 1}, {from: 5, count: 2}, {from: 15, count: 7}},
+			lhs:    []RowRange{{From: 0, Count: 2}, {From: 3, Count: 1}, {From: 5, Count: 2}, {From: 12, Count: 10}},
+			rhs:    []RowRange{{From: 0, Count: 10}, {From: 15, Count: 32}},
+			expect: []RowRange{{From: 0, Count: 2}, {From: 3, Count: 1}, {From: 5, Count: 2}, {From: 15, Count: 7}},
 		},
 		{
-			lhs:    []rowRange{},
-			rhs:    []rowRange{{from: 0, count: 10}},
-			expect: []rowRange{},
+			lhs:    []RowRange{},
+			rhs:    []RowRange{{From: 0, Count: 10}},
+			expect: []RowRange{},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 10}},
-			rhs:    []rowRange{},
-			expect: []rowRange{},
+			lhs:    []RowRange{{From: 0, Count: 10}},
+			rhs:    []RowRange{},
+			expect: []RowRange{},
 		},
 		{
-			lhs:    []rowRange{},
-			rhs:    []rowRange{},
-			expect: []rowRange{},
+			lhs:    []RowRange{},
+			rhs:    []RowRange{},
+			expect: []RowRange{},
 		},
 		{
-			lhs:    []rowRange{{from: 0, count: 2}},
-			rhs:    []rowRange{{from: 0, count: 1}, {from: 1, count: 1}, {from: 2, count: 1}},
-			expect: []rowRange{{from: 0, count: 2}},
+			lhs:    []RowRange{{From: 0, Count: 2}},
+			rhs:    []RowRange{{From: 0, Count: 1}, {From: 1, Count: 1}, {From: 2, Count: 1}},
+			expect: []RowRange{{From: 0, Count: 2}},
 		},
 	} {
 		t.Run("", func(t *testing.T) {
@@ -81,34 +81,34 @@ func TestIntersect(t *testing.T) {
 }
 
 func TestSimplify(t *testing.T) {
-	for _, tt := range []struct{ in, expect []rowRange }{
+	for _, tt := range []struct{ in, expect []RowRange }{
 		{
-			in: []rowRange{
-				{from: 0, count: 15},
-				{from: 4, count: 4},
+			in: []RowRange{
+				{From: 0, Count: 15},
+				{From: 4, Count: 4},
 			},
-			expect: []rowRange{
-				{from: 0, count: 15},
+			expect: []RowRange{
+				{From: 0, Count: 15},
 			},
 		},
 		{
-			in: []rowRange{
-				{from: 4, count: 4},
-				{from: 4, count: 2},
+			in: []RowRange{
+				{From: 4, Count: 4},
+				{From: 4, Count: 2},
 			},
-			expect: []rowRange{
-				{from: 4, count: 4},
+			expect: []RowRange{
+				{From: 4, Count: 4},
 			},
 		},
 		{
-			in: []rowRange{
-				{from: 0, count: 4},
-				{from: 1, count: 5},
-				{from: 8, count: 10},
+			in: []RowRange{
+				{From: 0, Count: 4},
+				{From: 1, Count: 5},
+				{From: 8, Count: 10},
 			},
-			expect: []rowRange{
-				{from: 0, count: 6},
-				{from: 8, count: 10},
+			expect: []RowRange{
+				{From: 0, Count: 6},
+				{From: 8, Count: 10},
 			},
 		},
 	} {
@@ -119,3 +119,66 @@ func TestSimplify(t *testing.T) {
 		})
 	}
 }
+
+func TestComplement(t *testing.T) {
+	for _, tt := range []struct{ lhs, rhs, expect []RowRange }{
+		{
+			lhs:    []RowRange{{From: 0, Count: 10}},
+			rhs:    []RowRange{{From: 2, Count: 3}},
+			expect: []RowRange{{From: 0, Count: 2}, {From: 5, Count: 5}},
+		},
+		{
+			lhs:    []RowRange{{From: 0, Count: 10}},
+			rhs:    []RowRange{},
+			expect: []RowRange{{From: 0, Count: 10}},
+		},
+		{
+			lhs:    []RowRange{{From: 0, Count: 10}},
+			rhs:    []RowRange{{From: 0, Count: 10}},
+			expect: []RowRange{},
+		},
+		{
+			lhs:    []RowRange{},
+			rhs:    []RowRange{{From: 0, Count: 10}},
+			expect: []RowRange{},
+		},
+		{
+			lhs:    []RowRange{{From: 0, Count: 4}, {From: 6, Count: 4}},
+			rhs:    []RowRange{{From: 2, Count: 6}},
+			expect: []RowRange{{From: 0, Count: 2}, {From: 8, Count: 2}},
+		},
+		{
+			lhs:    []RowRange{{From: 2, Count: 2}, {From: 8, Count: 2}},
+			rhs:    []RowRange{{From: 0, Count: 1}, {From: 5, Count: 1}, {From: 12, Count: 1}},
+			expect: []RowRange{{From: 2, Count: 2}, {From: 8, Count: 2}},
+		},
+		{
+			lhs:    []RowRange{{From: 0, Count: 10}},
+			rhs:    []RowRange{{From: 0, Count: 1}, {From: 3, Count: 1}, {From: 9, Count: 4}},
+			expect: []RowRange{{From: 1, Count: 2}, {From: 4, Count: 5}},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			if res := complementRowRanges(tt.lhs, tt.rhs); !slices.Equal(res, tt.expect) {
+				t.Fatalf("Expected %v to match %v", res, tt.expect)
+			}
+		})
+	}
+}
+
+func TestNumRows(t *testing.T) {
+	for _, tt := range []struct {
+		rr     RowRanges
+		expect int64
+	}{
+		{rr: nil, expect: 0},
+		{rr: RowRanges{{From: 3, Count: 4}}, expect: 4},
+		{rr: RowRanges{{From: 0, Count: 2}, {From: 5, Count: 1}, {From: 10, Count: 7}}, expect: 10},
+	} {
+		t.Run("", func(t *testing.T) {
+			if res := tt.rr.NumRows(); res != tt.expect {
+				t.Fatalf("Expected %d to match %d", res, tt.expect)
+			}
+		})
+	}
+}
@@ -5,12 +5,70 @@
 package search
 
 import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
 	"github.com/parquet-go/parquet-go"
 )
 
+// ErrCorruptBlock is returned when decoding a row group panics, which parquet-go
+// may do when reading malformed files.
+var ErrCorruptBlock = errors.New("corrupt block")
+
+// CorruptBlockError is the error returned for a panic recovered while decoding a row group.
+// It matches ErrCorruptBlock and carries the stack of the panic to locate where decoding failed.
+type CorruptBlockError struct {
+	Panic any
+	Stack []byte
+}
+
+func (e *CorruptBlockError) Error() string {
+	return fmt.Sprintf("%s: recovered from panic during filter: %v", ErrCorruptBlock, e.Panic)
+}
+
+func (e *CorruptBlockError) Unwrap() error {
+	return ErrCorruptBlock
+}
+
 type Constraint interface {
 	// rowRanges returns a set of non-overlapping increasing row indexes that may satisfy the constraint.
-	rowRanges(rg parquet.RowGroup, rr []rowRange) ([]rowRange, error)
+	rowRanges(rg parquet.RowGroup, rr []RowRange) ([]RowRange, error)
 	// init initializes the constraint with respect to the file schema and projections.
 	init(s *parquet.Schema) error
 }
+
+// Initialize initializes all constraints with respect to the file schema.
+func Initialize(s *parquet.Schema, cs ...Constraint) error {
+	for i := range cs {
+		if err := cs[i].init(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter returns the row ranges of the row group that satisfy all constraints.
+// Constraints are applied conjunctively: every constraint is only evaluated on the
+// rows that satisfied the previous ones and its result is intersected with them,
+// so multiple constraints on the same column narrow the result instead of replacing it.
+// A panic while decoding the row group is recovered and returned as a CorruptBlockError.
+func Filter(rg parquet.RowGroup, cs ...Constraint) (_ RowRanges, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CorruptBlockError{Panic: r, Stack: debug.Stack()}
+		}
+	}()
+
+	rr := []RowRange{{From: 0, Count: rg.NumRows()}}
+	for i := range cs {
+		srr, err := cs[i].rowRanges(rg, rr)
+		if err != nil {
+			return nil, err
+		}
+		if rr = intersectRowRanges(rr, srr); len(rr) == 0 {
+			return nil, nil
+		}
+	}
+	return rr, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Cloudflare, Inc.
+// Licensed under the Apache 2.0 license found in the LICENSE file or at:
+//     https://opensource.org/licenses/Apache-2.0
+
+package search
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type fixedConstraint struct {
+	rr   []RowRange
+	seen []RowRange
+}
+
+func (fc *fixedConstraint) rowRanges(_ parquet.RowGroup, rr []RowRange) ([]RowRange, error) {
+	fc.seen = rr
+	return fc.rr, nil
+}
+
+func (fc *fixedConstraint) init(_ *parquet.Schema) error { return nil }
+
+type panicConstraint struct{}
+
+func (pc *panicConstraint) rowRanges(_ parquet.RowGroup, _ []RowRange) ([]RowRange, error) {
+	panic("unexpected page encoding")
+}
+
+func (pc *panicConstraint) init(_ *parquet.Schema) error { return nil }
+
+func TestFilter(t *testing.T) {
+	type row struct {
+		Job string `parquet:"job"`
+	}
+	buf := parquet.NewGenericBuffer[row]()
+	if _, err := buf.Write(make([]row, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no constraints match all rows", func(t *testing.T) {
+		rr, err := Filter(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := []RowRange{{From: 0, Count: 10}}; !slices.Equal(rr, expect) {
+			t.Fatalf("Expected %v to match %v", rr, expect)
+		}
+	})
+	t.Run("constraints are applied conjunctively", func(t *testing.T) {
+		first := &fixedConstraint{rr: []RowRange{{From: 0, Count: 6}}}
+		second := &fixedConstraint{rr: []RowRange{{From: 4, Count: 6}}}
+
+		rr, err := Filter(buf, first, second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := []RowRange{{From: 4, Count: 2}}; !slices.Equal(rr, expect) {
+			t.Fatalf("Expected %v to match %v", rr, expect)
+		}
+		if expect := []RowRange{{From: 0, Count: 6}}; !slices.Equal(second.seen, expect) {
+			t.Fatalf("Expected second constraint to see %v, got %v", expect, second.seen)
+		}
+	})
+	t.Run("disjoint constraints match nothing", func(t *testing.T) {
+		first := &fixedConstraint{rr: []RowRange{{From: 0, Count: 3}}}
+		second := &fixedConstraint{rr: []RowRange{{From: 5, Count: 3}}}
+		third := &fixedConstraint{rr: []RowRange{{From: 0, Count: 10}}}
+
+		rr, err := Filter(buf, first, second, third)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rr) != 0 {
+			t.Fatalf("Expected no rows, got %v", rr)
+		}
+		if third.seen != nil {
+			t.Fatalf("Expected third constraint to be skipped, got %v", third.seen)
+		}
+	})
+}
+
+func TestFilterCorruptBlock(t *testing.T) {
+	type row struct {
+		Job string `parquet:"job"`
+	}
+	rows := make([]row, 100)
+	for i := range rows {
+		rows[i].Job = "a"
+	}
+
+	t.Run("panics are returned as errors", func(t *testing.T) {
+		file := buildFile(t, rows)
+		_, err := Filter(file.RowGroups()[0], &panicConstraint{})
+		if !errors.Is(err, ErrCorruptBlock) {
+			t.Fatalf("Expected %v to be %v", err, ErrCorruptBlock)
+		}
+		var cerr *CorruptBlockError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("Expected %v to be a CorruptBlockError", err)
+		}
+		if !strings.Contains(string(cerr.Stack), "(*panicConstraint).rowRanges") {
+			t.Fatalf("Expected the stack of the panic, got %s", cerr.Stack)
+		}
+		if strings.Contains(err.Error(), "\n") {
+			t.Fatalf("Expected a single line error, got %q", err)
+		}
+	})
+	t.Run("malformed page header returns an error", func(t *testing.T) {
+		data := writeFile(t, [][]row{rows})
+
+		// overwrite the first page header that follows the leading magic bytes,
+		// parquet-go fails to decode it and returns an error without panicking
+		for i := 4; i < 12; i++ {
+			data[i] = 0xff
+		}
+		if _, err := filterFile(t, data, Equal("job", parquet.ValueOf("a"))); err == nil {
+			t.Fatal("Expected an error for malformed page header")
+		}
+	})
+	t.Run("malformed page values are returned as ErrCorruptBlock", func(t *testing.T) {
+		data := writeFile(t, [][]row{rows})
+
+		// the data page header encodes its number of values as the varint c8 01 (100),
+		// claiming 2120 values instead makes parquet-go slice past the end of the page
+		i := bytes.Index(data, []byte{0x15, 0xc8, 0x01})
+		if i < 0 {
+			t.Fatal("Expected to find the number of values in the page header")
+		}
+		data[i+2] = 0x10
+
+		_, err := filterFile(t, data, Equal("job", parquet.ValueOf("a")))
+		if !errors.Is(err, ErrCorruptBlock) {
+			t.Fatalf("Expected %v to be %v", err, ErrCorruptBlock)
+		}
+	})
+}
+
+func filterFile(t testing.TB, data []byte, cs ...Constraint) (RowRanges, error) {
+	file := openFile(t, data)
+	if err := Initialize(file.Schema(), cs...); err != nil {
+		t.Fatal(err)
+	}
+	return Filter(file.RowGroups()[0], cs...)
+}